@@ -1,5 +1,7 @@
 package log
 
+import "time"
+
 type Config struct {
 	// LogDir dir for logs
 	LogDir string `mapstructure:"dir,omitempty" yaml:"dir,omitempty" json:"dir,omitempty"`
@@ -16,14 +18,81 @@ type Config struct {
 	// using gzip. The default is not to perform compression.
 	Compress bool `mapstructure:"compress,omitempty" yaml:"compress,omitempty" json:"compress,omitempty"`
 
-	// LogLevel the level for output log eg debug info warn error
+	// LogLevel the level for output log eg debug info warn error. Used as the default
+	// for ConsoleLevel/FileLevel when those are not set.
 	LogLevel string `mapstructure:"level" yaml:"level" json:"level"`
-	// JsonEncode json format logs
+	// JsonEncode json format logs. Deprecated: use FileJson/ConsoleJson instead. Still
+	// honored as the default for FileJson when FileJson is unset.
 	JsonEncode bool `mapstructure:"jsonEncode,omitempty" yaml:"jsonEncode,omitempty" json:"jsonEncode,omitempty"`
 	// StacktraceLevel output stack track for this level log eg error
 	StacktraceLevel string `mapstructure:"stacktraceLevel,omitempty" yaml:"stacktraceLevel,omitempty" json:"stacktraceLevel,omitempty"`
-	// Stdout output log to stdout
+	// Stdout output log to stdout. Deprecated: use ConsoleEnabled instead. Still honored
+	// as the default for ConsoleEnabled when ConsoleEnabled is unset.
 	Stdout bool `mapstructure:"stdout,omitempty" yaml:"stdout,omitempty" json:"stdout,omitempty"`
 	// FilePerLevel Each level of log output to the corresponding log file. eg debug.log info.log warn.log error.log
 	FilePerLevel bool `mapstructure:"filePerLevel,omitempty" yaml:"filePerLevel,omitempty" json:"filePerLevel,omitempty"`
+
+	// ConsoleEnabled enables the console (stdout) sink. Defaults to Stdout for
+	// backward compatibility when unset.
+	ConsoleEnabled bool `mapstructure:"consoleEnabled,omitempty" yaml:"consoleEnabled,omitempty" json:"consoleEnabled,omitempty"`
+	// ConsoleLevel is the minimum level written to the console sink. Defaults to LogLevel.
+	ConsoleLevel string `mapstructure:"consoleLevel,omitempty" yaml:"consoleLevel,omitempty" json:"consoleLevel,omitempty"`
+	// ConsoleJson encodes the console sink as JSON instead of colorized, human-readable text.
+	ConsoleJson bool `mapstructure:"consoleJson,omitempty" yaml:"consoleJson,omitempty" json:"consoleJson,omitempty"`
+
+	// FileEnabled enables the rotated file sink. A nil value defaults to true for
+	// backward compatibility, since the file sink has always been on; set it to
+	// an explicit false to get console-only output.
+	FileEnabled *bool `mapstructure:"fileEnabled,omitempty" yaml:"fileEnabled,omitempty" json:"fileEnabled,omitempty"`
+	// FileLevel is the minimum level written to the file sink. Defaults to LogLevel.
+	FileLevel string `mapstructure:"fileLevel,omitempty" yaml:"fileLevel,omitempty" json:"fileLevel,omitempty"`
+	// FileJson encodes the file sink as JSON. Defaults to JsonEncode.
+	FileJson bool `mapstructure:"fileJson,omitempty" yaml:"fileJson,omitempty" json:"fileJson,omitempty"`
+
+	// Sinks configures additional pluggable log sinks (syslog, Kafka, Loki, ...)
+	// resolved through the sink registry. See RegisterSink.
+	Sinks []SinkConfig `mapstructure:"sinks,omitempty" yaml:"sinks,omitempty" json:"sinks,omitempty"`
+
+	// Sampling caps the rate of repeated (level, message) entries to protect hot
+	// paths from log floods; nil disables sampling. Defaults to zap's production
+	// sampling (100 initial, 100 thereafter, 1s tick) once JSON encoding is
+	// enabled on any sink; set explicitly to override.
+	Sampling *SamplingConfig `mapstructure:"sampling,omitempty" yaml:"sampling,omitempty" json:"sampling,omitempty"`
+
+	// consoleLevelDefaulted/fileLevelDefaulted record whether ConsoleLevel/FileLevel
+	// were left unset and so defaulted from LogLevel, as opposed to being pinned to
+	// an explicit value independent of it. buildCore uses this to decide whether the
+	// sink's floor should keep tracking LogLevel live (via SetLevel) or stay fixed.
+	consoleLevelDefaulted bool
+	fileLevelDefaulted    bool
+}
+
+// applyDefaults fills the console/file sink settings from their legacy
+// counterparts (Stdout, LogLevel, JsonEncode), so existing configs keep their
+// sinks, levels and file encoding. The console sink's encoding comes from
+// ConsoleJson alone, so a legacy Stdout config now gets colorized text on the
+// console; and when Sampling is nil, any JSON encoding turns on zap's
+// production sampling.
+func (c *Config) applyDefaults() {
+	if !c.ConsoleEnabled && c.Stdout {
+		c.ConsoleEnabled = true
+	}
+	if c.FileEnabled == nil {
+		enabled := true
+		c.FileEnabled = &enabled
+	}
+	if c.ConsoleLevel == "" {
+		c.ConsoleLevel = c.LogLevel
+		c.consoleLevelDefaulted = true
+	}
+	if c.FileLevel == "" {
+		c.FileLevel = c.LogLevel
+		c.fileLevelDefaulted = true
+	}
+	if !c.FileJson {
+		c.FileJson = c.JsonEncode
+	}
+	if c.Sampling == nil && (c.JsonEncode || c.FileJson || c.ConsoleJson) {
+		c.Sampling = &SamplingConfig{Initial: 100, Thereafter: 100, Tick: time.Second}
+	}
 }
@@ -0,0 +1,33 @@
+package log
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ContextFieldExtractor pulls zap.Fields out of a context, eg trace/span/request
+// IDs, so they can be attached to every log line without callers passing them
+// by hand. See RegisterContextExtractor.
+type ContextFieldExtractor func(ctx context.Context) []zap.Field
+
+var contextExtractors []ContextFieldExtractor
+
+// RegisterContextExtractor registers an extractor consulted by WithContext.
+// Extractors run in registration order and their fields are appended, in
+// order, to the logger WithContext returns.
+func RegisterContextExtractor(extractor ContextFieldExtractor) {
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// contextFields runs every registered extractor against ctx.
+func contextFields(ctx context.Context) []zap.Field {
+	if len(contextExtractors) == 0 {
+		return nil
+	}
+	var fields []zap.Field
+	for _, extractor := range contextExtractors {
+		fields = append(fields, extractor(ctx)...)
+	}
+	return fields
+}
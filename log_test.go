@@ -0,0 +1,129 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSamplingDropsExcessEntries(t *testing.T) {
+	tests := []struct {
+		name       string
+		initial    int
+		thereafter int
+		calls      int
+		want       int
+	}{
+		{name: "under initial burst", initial: 100, thereafter: 100, calls: 50, want: 50},
+		{name: "over initial burst", initial: 10, thereafter: 5, calls: 30, want: 10 + (30-10)/5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observed, logs := observer.New(zap.DebugLevel)
+			core := maybeSample(&Config{Sampling: &SamplingConfig{
+				Initial:    tt.initial,
+				Thereafter: tt.thereafter,
+				Tick:       time.Minute,
+			}}, observed)
+			l := zap.New(core)
+			for i := 0; i < tt.calls; i++ {
+				l.Info("same message")
+			}
+			if got := logs.Len(); got != tt.want {
+				t.Errorf("logged %d entries, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSamplingNoSampleKeyBypassesSampler(t *testing.T) {
+	observed, logs := observer.New(zap.DebugLevel)
+	core := maybeSample(&Config{Sampling: &SamplingConfig{
+		Initial:    1,
+		Thereafter: 1000,
+		Tick:       time.Minute,
+	}}, observed)
+	l := zap.New(core)
+	for i := 0; i < 10; i++ {
+		l.Info("same message", zap.Bool(NoSampleKey, true))
+	}
+	if got := logs.Len(); got != 10 {
+		t.Errorf("logged %d entries, want all 10 to bypass sampling", got)
+	}
+}
+
+func TestFilePerLevelRoutingIsolatesLevels(t *testing.T) {
+	dir := t.TempDir()
+	enabled := true
+	l := New(&Config{
+		LogDir:       dir,
+		FilePerLevel: true,
+		FileEnabled:  &enabled,
+		LogLevel:     "debug",
+	})
+	l.Debug("a debug line")
+	l.Info("an info line")
+	l.Warn("a warn line")
+	l.Error("an error line")
+
+	checks := []struct {
+		file        string
+		wantContain string
+	}{
+		{"debug.log", "a debug line"},
+		{"info.log", "an info line"},
+		{"warn.log", "a warn line"},
+		{"error.log", "an error line"},
+	}
+	for _, c := range checks {
+		data, err := os.ReadFile(filepath.Join(dir, c.file))
+		if err != nil {
+			t.Fatalf("reading %s: %v", c.file, err)
+		}
+		content := string(data)
+		if !strings.Contains(content, c.wantContain) {
+			t.Errorf("%s missing %q, got %q", c.file, c.wantContain, content)
+		}
+		for _, other := range checks {
+			if other.file == c.file {
+				continue
+			}
+			if strings.Contains(content, other.wantContain) {
+				t.Errorf("%s unexpectedly contains %q from %s", c.file, other.wantContain, other.file)
+			}
+		}
+	}
+}
+
+func TestSetLevelWidensDefaultedSinks(t *testing.T) {
+	dir := t.TempDir()
+	enabled := true
+	l := New(&Config{
+		LogDir:      dir,
+		FileEnabled: &enabled,
+		LogFile:     "all.log",
+		LogLevel:    "info",
+	})
+	l.Debug("should not appear yet")
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	l.Debug("should appear after widening")
+
+	data, err := os.ReadFile(filepath.Join(dir, "all.log"))
+	if err != nil {
+		t.Fatalf("reading log file: %v", err)
+	}
+	content := string(data)
+	if strings.Contains(content, "should not appear yet") {
+		t.Errorf("debug line logged before SetLevel widened the floor")
+	}
+	if !strings.Contains(content, "should appear after widening") {
+		t.Errorf("debug line missing after SetLevel widened the floor, got %q", content)
+	}
+}
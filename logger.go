@@ -2,9 +2,12 @@ package log
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 type Logger interface {
@@ -38,18 +41,51 @@ type Logger interface {
 	Error(msg string, fields ...zap.Field)
 
 	Fatal(msg string, fields ...zap.Field)
+
+	// Debugw logs a message with some key-value pairs, eg Debugw("failed", "err", err, "attempt", 3).
+	Debugw(msg string, keysAndValues ...interface{})
+
+	Infow(msg string, keysAndValues ...interface{})
+
+	Warnw(msg string, keysAndValues ...interface{})
+
+	Errorw(msg string, keysAndValues ...interface{})
+
+	Fatalw(msg string, keysAndValues ...interface{})
+
+	// SetLevel changes the minimum enabled level at runtime for sinks whose own
+	// level was left unset; sinks pinned to an explicit ConsoleLevel/FileLevel keep
+	// it, and with FilePerLevel set the per-file bucket routing stays fixed. It
+	// returns an error if level can't be parsed, or if this logger carries no
+	// atomic level.
+	SetLevel(level string) error
+
+	// Level reports the logger's current minimum enabled level.
+	Level() zapcore.Level
 }
 
 type baseLogger struct {
 	*zap.Logger
+	level *zap.AtomicLevel
 }
 
 var logger Logger
 
-// New create logger with options and init global logger
+// New create logger with options and init global logger. The logger's sinks are
+// wrapped so a later call to Reload can swap them live.
 func New(opts *Config) Logger {
-	l := Zap(opts)
-	logger = &baseLogger{l}
+	core, level, err := buildCore(opts, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "log: %v\n", err)
+	}
+	rootCore.set(core)
+	rootLevel = level
+	if opts.StacktraceLevel == "" {
+		opts.StacktraceLevel = "error"
+	}
+	l := zap.New(&swappableCore{holder: rootCore}, zap.AddCaller(), zap.AddCallerSkip(2),
+		zap.AddStacktrace(logLevel(opts.StacktraceLevel)))
+	logger = &baseLogger{l, level}
 	// replaces the zap global Logger and SugaredLogger
 	zap.ReplaceGlobals(l)
 	return logger
@@ -112,6 +148,26 @@ func Fatal(msg string, fields ...zap.Field) {
 	defaultLogger().Fatal(msg, fields...)
 }
 
+func Debugw(msg string, keysAndValues ...interface{}) {
+	defaultLogger().Debugw(msg, keysAndValues...)
+}
+
+func Infow(msg string, keysAndValues ...interface{}) {
+	defaultLogger().Infow(msg, keysAndValues...)
+}
+
+func Warnw(msg string, keysAndValues ...interface{}) {
+	defaultLogger().Warnw(msg, keysAndValues...)
+}
+
+func Errorw(msg string, keysAndValues ...interface{}) {
+	defaultLogger().Errorw(msg, keysAndValues...)
+}
+
+func Fatalw(msg string, keysAndValues ...interface{}) {
+	defaultLogger().Fatalw(msg, keysAndValues...)
+}
+
 func WithName(name string) Logger {
 	return defaultLogger().WithName(name).AddCallerSkip(-1)
 }
@@ -123,3 +179,27 @@ func WithValues(keysAndValues ...interface{}) Logger {
 func WithContext(ctx context.Context) Logger {
 	return defaultLogger().WithContext(ctx)
 }
+
+// SetLevel changes the package-level logger's minimum enabled level at runtime.
+func SetLevel(level string) error {
+	return defaultLogger().SetLevel(level)
+}
+
+// Level reports the package-level logger's current minimum enabled level.
+func Level() zapcore.Level {
+	return defaultLogger().Level()
+}
+
+// LevelHandler returns an http.Handler that serves the package-level logger's
+// level as JSON, mirroring zap.AtomicLevel.ServeHTTP: GET reports the current
+// level, PUT {"level":"debug"} changes it at runtime.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l, ok := defaultLogger().(*baseLogger)
+		if !ok || l.level == nil {
+			http.Error(w, "log: dynamic level control is not enabled for this logger", http.StatusNotImplemented)
+			return
+		}
+		l.level.ServeHTTP(w, r)
+	})
+}
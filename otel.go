@@ -0,0 +1,26 @@
+package log
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func init() {
+	RegisterContextExtractor(otelContextFields)
+}
+
+// otelContextFields pulls trace_id/span_id out of ctx's OpenTelemetry span, if
+// any, so every log line emitted via WithContext is automatically correlated
+// with distributed traces.
+func otelContextFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	}
+}
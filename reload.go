@@ -0,0 +1,122 @@
+package log
+
+import (
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// coreHolder lets the live core backing the package-global logger be swapped
+// out from under already-constructed *zap.Logger values.
+type coreHolder struct {
+	mu   sync.RWMutex
+	core zapcore.Core
+}
+
+func (h *coreHolder) get() zapcore.Core {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.core
+}
+
+func (h *coreHolder) set(core zapcore.Core) {
+	h.mu.Lock()
+	h.core = core
+	h.mu.Unlock()
+}
+
+// swappableCore is a zapcore.Core that always delegates to whatever core is
+// currently in holder, re-applying any fields accumulated via With (eg from
+// WithName/WithValues) on top of the live core. This is what lets Reload take
+// effect for Logger values callers are already holding.
+type swappableCore struct {
+	holder *coreHolder
+	fields []zapcore.Field
+}
+
+func (s *swappableCore) live() zapcore.Core {
+	core := s.holder.get()
+	if len(s.fields) > 0 {
+		core = core.With(s.fields)
+	}
+	return core
+}
+
+func (s *swappableCore) Enabled(lvl zapcore.Level) bool {
+	return s.live().Enabled(lvl)
+}
+
+func (s *swappableCore) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(s.fields)+len(fields))
+	merged = append(merged, s.fields...)
+	merged = append(merged, fields...)
+	return &swappableCore{holder: s.holder, fields: merged}
+}
+
+// Check delegates straight to the live core's own Check so that core (eg a
+// multiCore tee) decides, per leaf, whether ent applies to it - AddCore then
+// registers that leaf core directly, not s, so Write never has to re-dispatch.
+func (s *swappableCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return s.live().Check(ent, ce)
+}
+
+// Write only runs if something calls it on s directly rather than through
+// Check/AddCore; forward to the live core for consistency with Check.
+func (s *swappableCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return s.live().Write(ent, fields)
+}
+
+func (s *swappableCore) Sync() error {
+	return s.live().Sync()
+}
+
+var (
+	rootCore  = &coreHolder{}
+	rootLevel *zap.AtomicLevel
+)
+
+// Reload atomically swaps the sinks, level, and rotation settings behind the
+// package-global logger to match opts, without invalidating Logger values
+// callers already hold from WithName, WithValues, or WithContext - they keep
+// routing through the same swappable core and pick up opts live. Call New
+// first; Reload before that just behaves like New.
+func Reload(opts *Config) error {
+	if rootLevel == nil {
+		New(opts)
+		return nil
+	}
+	core, _, err := buildCore(opts, rootLevel)
+	rootCore.set(core)
+	return err
+}
+
+// Watch loads Config from path (any format viper supports, keyed by the same
+// mapstructure tags Config already carries), calls Reload with it, and then
+// keeps watching path for changes via fsnotify, calling Reload again on every
+// write. It returns once the initial load and Reload succeed; watching
+// continues in the background for the life of the process.
+func Watch(path string) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return err
+	}
+	load := func() error {
+		var opts Config
+		if err := v.Unmarshal(&opts); err != nil {
+			return err
+		}
+		return Reload(&opts)
+	}
+	if err := load(); err != nil {
+		return err
+	}
+	v.OnConfigChange(func(fsnotify.Event) {
+		_ = load()
+	})
+	v.WatchConfig()
+	return nil
+}
@@ -0,0 +1,47 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReloadAffectsAlreadyCapturedLoggers(t *testing.T) {
+	dir := t.TempDir()
+	enabled := true
+	New(&Config{
+		LogDir:      dir,
+		FileEnabled: &enabled,
+		LogFile:     "reload.log",
+		LogLevel:    "info",
+	})
+
+	// Simulate a caller that stashed a derived logger, eg via
+	// log.WithValues(...).WithContext(ctx), before any Reload happens.
+	captured := WithValues("component", "worker")
+	captured.Debug("before reload, should be dropped")
+
+	if err := Reload(&Config{
+		LogDir:      dir,
+		FileEnabled: &enabled,
+		LogFile:     "reload.log",
+		LogLevel:    "debug",
+	}); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	captured.Debug("after reload, should be logged")
+
+	data, err := os.ReadFile(filepath.Join(dir, "reload.log"))
+	if err != nil {
+		t.Fatalf("reading log file: %v", err)
+	}
+	content := string(data)
+	if strings.Contains(content, "before reload") {
+		t.Errorf("captured logger logged a debug line before Reload widened the level")
+	}
+	if !strings.Contains(content, "after reload, should be logged") {
+		t.Errorf("captured logger did not pick up Reload's widened level, got %q", content)
+	}
+}
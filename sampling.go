@@ -0,0 +1,95 @@
+package log
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig configures burst protection for a sink: the first Initial
+// entries of each (level, message) within a Tick window are logged, then every
+// Thereafter-th one after that. See zapcore.NewSamplerWithOptions.
+type SamplingConfig struct {
+	// Initial is the number of entries of each (level, message) logged per Tick
+	// before sampling kicks in.
+	Initial int `mapstructure:"initial,omitempty" yaml:"initial,omitempty" json:"initial,omitempty"`
+	// Thereafter is the sampling rate once Initial is exceeded within a Tick; every
+	// Thereafter-th entry is logged.
+	Thereafter int `mapstructure:"thereafter,omitempty" yaml:"thereafter,omitempty" json:"thereafter,omitempty"`
+	// Tick is the sampling window. Defaults to 1s.
+	Tick time.Duration `mapstructure:"tick,omitempty" yaml:"tick,omitempty" json:"tick,omitempty"`
+}
+
+// NoSampleKey is a WithValues/Debugw-style key that, set to true, makes a call
+// bypass sampling entirely, eg l.WithValues(log.NoSampleKey, true).Error(...)
+// on a Logger l, or log.Debugw(msg, log.NoSampleKey, true).
+const NoSampleKey = "_nosample"
+
+// maybeSample wraps core with Config.Sampling, if set. The wrapper still lets
+// entries carrying a NoSampleKey=true field through unsampled.
+func maybeSample(opts *Config, core zapcore.Core) zapcore.Core {
+	if opts.Sampling == nil {
+		return core
+	}
+	tick := opts.Sampling.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	sampler := zapcore.NewSamplerWithOptions(core, tick, opts.Sampling.Initial, opts.Sampling.Thereafter)
+	return &sampledCore{sampler: sampler, raw: core}
+}
+
+// sampledCore lets an entry skip sampling by carrying a NoSampleKey=true field,
+// on the call itself or added earlier via With, writing it straight to raw
+// instead of through sampler.
+type sampledCore struct {
+	sampler zapcore.Core
+	raw     zapcore.Core
+	// bypass is set once a NoSampleKey=true field has been added via With.
+	bypass bool
+}
+
+func (s *sampledCore) Enabled(lvl zapcore.Level) bool {
+	return s.raw.Enabled(lvl)
+}
+
+func (s *sampledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sampledCore{sampler: s.sampler.With(fields), raw: s.raw.With(fields), bypass: s.bypass || noSample(fields)}
+}
+
+func (s *sampledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !s.Enabled(ent.Level) {
+		return ce
+	}
+	return ce.AddCore(ent, s)
+}
+
+// Write is where the NoSampleKey bypass is decided, since per-call fields (and
+// so the bypass flag) aren't available yet at Check time; a sampled write runs the
+// entry through sampler.Check itself so zap's own (level, message) counting -
+// not a reimplementation of it - decides whether this one gets dropped.
+func (s *sampledCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if s.bypass || noSample(fields) {
+		return s.raw.Write(ent, fields)
+	}
+	sce := s.sampler.Check(ent, nil)
+	if sce == nil {
+		return nil
+	}
+	sce.Write(fields...)
+	return nil
+}
+
+func (s *sampledCore) Sync() error {
+	return s.raw.Sync()
+}
+
+// noSample reports whether fields carries a NoSampleKey=true bool field.
+func noSample(fields []zapcore.Field) bool {
+	for _, f := range fields {
+		if f.Key == NoSampleKey && f.Type == zapcore.BoolType {
+			return f.Integer == 1
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRegisterSinkOverwritesExistingFactory(t *testing.T) {
+	const name = "test-fake-sink"
+	var calledWith string
+	RegisterSink(name, func(opts *Config, sink SinkConfig) (zapcore.Core, error) {
+		calledWith = "first"
+		core, _ := observer.New(logLevel(opts.LogLevel))
+		return core, nil
+	})
+	RegisterSink(name, func(opts *Config, sink SinkConfig) (zapcore.Core, error) {
+		calledWith = "second"
+		core, _ := observer.New(logLevel(opts.LogLevel))
+		return core, nil
+	})
+
+	_, err := pluggableCores(&Config{Sinks: []SinkConfig{{Type: name}}})
+	if err != nil {
+		t.Fatalf("pluggableCores: %v", err)
+	}
+	if calledWith != "second" {
+		t.Errorf("resolved factory = %q, want the later registration to win", calledWith)
+	}
+}
+
+func TestPluggableCoresSkipsUnregisteredTypeButKeepsOthers(t *testing.T) {
+	const name = "test-fake-sink-2"
+	RegisterSink(name, func(opts *Config, sink SinkConfig) (zapcore.Core, error) {
+		core, _ := observer.New(logLevel(opts.LogLevel))
+		return core, nil
+	})
+
+	cores, err := pluggableCores(&Config{Sinks: []SinkConfig{
+		{Type: "test-unregistered-sink"},
+		{Type: name},
+	}})
+	if len(cores) != 1 {
+		t.Errorf("got %d cores, want 1 (the registered sink's)", len(cores))
+	}
+	if err == nil {
+		t.Error("expected an error reporting the unregistered sink type")
+	}
+}
+
+func TestPluggableCoresFactoryError(t *testing.T) {
+	const name = "test-failing-sink"
+	wantErr := errors.New("boom")
+	RegisterSink(name, func(opts *Config, sink SinkConfig) (zapcore.Core, error) {
+		return nil, wantErr
+	})
+
+	cores, err := pluggableCores(&Config{Sinks: []SinkConfig{{Type: name}}})
+	if len(cores) != 0 {
+		t.Errorf("got %d cores, want 0 when the factory errors", len(cores))
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("pluggableCores error = %v, want it to wrap %v", err, wantErr)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package log
+
+import (
+	"log/syslog"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	RegisterSink("syslog", syslogSinkFactory)
+}
+
+// syslogSinkFactory builds a core that writes to a syslog daemon. Recognized
+// sink.Options:
+//   - network: "" for the local syslog daemon, or "tcp"/"udp" for a remote one
+//   - address: remote syslogd address, required when network is set
+//   - tag: syslog tag, defaults to the program name
+func syslogSinkFactory(opts *Config, sink SinkConfig) (zapcore.Core, error) {
+	network, _ := sink.Options["network"].(string)
+	address, _ := sink.Options["address"].(string)
+	tag, _ := sink.Options["tag"].(string)
+
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	core := zapcore.NewCore(newEncoder(opts.FileJson, false), zapcore.AddSync(w), sinkLevel(opts, sink))
+	return maybeSample(opts, core), nil
+}
@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,6 +17,46 @@ const LoggerKey = "_logger"
 
 // Zap init zap logger
 func Zap(opts *Config) *zap.Logger {
+	l, _ := zapWithLevel(opts)
+	return l
+}
+
+// ZapWithLevel is Zap plus the *zap.AtomicLevel driving the logger's level;
+// use it instead of Zap when you need to adjust the level at runtime (via the
+// returned level's SetLevel) on a *zap.Logger built outside the package-global
+// New. The atomic level drives the sinks left at their default level
+// (ConsoleLevel/FileLevel unset); with FilePerLevel set, bucket routing itself
+// stays fixed at construction time.
+func ZapWithLevel(opts *Config) (*zap.Logger, *zap.AtomicLevel) {
+	return zapWithLevel(opts)
+}
+
+func zapWithLevel(opts *Config) (*zap.Logger, *zap.AtomicLevel) {
+	core, level, err := buildCore(opts, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "log: %v\n", err)
+	}
+	if opts.StacktraceLevel == "" {
+		opts.StacktraceLevel = "error"
+	}
+	l := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(2),
+		zap.AddStacktrace(logLevel(opts.StacktraceLevel)))
+	return l, level
+}
+
+// buildCore resolves opts into the single (teed) zapcore.Core backing a logger,
+// plus the *zap.AtomicLevel driving the sinks left at their default level, in
+// both the single-file and the FilePerLevel branch. Pass a non-nil
+// level to reuse and retarget an existing atomic level (as Reload does) instead
+// of minting a new one, so loggers already holding it keep working live.
+func buildCore(opts *Config, level *zap.AtomicLevel) (zapcore.Core, *zap.AtomicLevel, error) {
+	opts.applyDefaults()
+	if level == nil {
+		lvl := zap.NewAtomicLevelAt(logLevel(opts.LogLevel))
+		level = &lvl
+	} else {
+		level.SetLevel(logLevel(opts.LogLevel))
+	}
 	var cores []zapcore.Core
 	if opts.FilePerLevel {
 		// Each level of log output to the corresponding log file. eg debug.log info.log warn.log error.log
@@ -31,20 +72,52 @@ func Zap(opts *Config) *zap.Logger {
 		errorPriority := zap.LevelEnablerFunc(func(lev zapcore.Level) bool {
 			return lev >= zap.ErrorLevel
 		})
-		cores = append(cores, NewZapCore(opts, "debug.log", debugPriority),
-			NewZapCore(opts, "info.log", infoPriority),
-			NewZapCore(opts, "warn.log", warnPriority),
-			NewZapCore(opts, "error.log", errorPriority))
+		cores = append(cores, sinkCores(opts, "debug.log", debugPriority, level)...)
+		cores = append(cores, sinkCores(opts, "info.log", infoPriority, level)...)
+		cores = append(cores, sinkCores(opts, "warn.log", warnPriority, level)...)
+		cores = append(cores, sinkCores(opts, "error.log", errorPriority, level)...)
 	} else {
 		// only one log file for all log level
-		defaultLevel := zap.NewAtomicLevelAt(logLevel(opts.LogLevel))
-		cores = append(cores, NewZapCore(opts, opts.LogFile, defaultLevel))
+		cores = append(cores, sinkCores(opts, opts.LogFile, level, level)...)
 	}
-	if opts.StacktraceLevel == "" {
-		opts.StacktraceLevel = "error"
+	// additional pluggable sinks (syslog, Kafka, Loki, ...) resolved by name via RegisterSink
+	extra, err := pluggableCores(opts)
+	cores = append(cores, extra...)
+	return zapcore.NewTee(cores...), level, err
+}
+
+// sinkCores builds the enabled file/console cores for a single log destination
+// (a rotated file name, or a FilePerLevel bucket), each additionally floored by
+// its own sink-specific level. level is the live atomic level for this core;
+// a sink whose own level was left unset tracks it instead of a fixed floor, so
+// SetLevel can widen that sink's verbosity, not just narrow it.
+func sinkCores(opts *Config, fileName string, priority zapcore.LevelEnabler, level *zap.AtomicLevel) []zapcore.Core {
+	var cores []zapcore.Core
+	if opts.FileEnabled != nil && *opts.FileEnabled {
+		cores = append(cores, NewZapCore(opts, fileName, withMinLevel(priority, sinkLevelFloor(opts.FileLevel, opts.fileLevelDefaulted, level))))
 	}
-	return zap.New(zapcore.NewTee(cores...), zap.AddCaller(), zap.AddCallerSkip(2),
-		zap.AddStacktrace(logLevel(opts.StacktraceLevel)))
+	if opts.ConsoleEnabled {
+		cores = append(cores, NewConsoleCore(opts, withMinLevel(priority, sinkLevelFloor(opts.ConsoleLevel, opts.consoleLevelDefaulted, level))))
+	}
+	return cores
+}
+
+// sinkLevelFloor picks the LevelEnabler backing a sink's minimum level: the
+// live atomic level if the sink was left to default to LogLevel, or the fixed
+// level it was explicitly pinned to otherwise.
+func sinkLevelFloor(configured string, defaulted bool, level *zap.AtomicLevel) zapcore.LevelEnabler {
+	if defaulted {
+		return level
+	}
+	return logLevel(configured)
+}
+
+// withMinLevel combines a priority enabler (eg a FilePerLevel bucket) with a
+// sink's own minimum level floor.
+func withMinLevel(priority, min zapcore.LevelEnabler) zapcore.LevelEnabler {
+	return zap.LevelEnablerFunc(func(lev zapcore.Level) bool {
+		return priority.Enabled(lev) && min.Enabled(lev)
+	})
 }
 
 // newWriteSyncer new file writer , fileName can empty, will use Config.LogFile
@@ -66,52 +139,70 @@ func newWriteSyncer(opts *Config, fileName string) zapcore.WriteSyncer {
 	return zapcore.AddSync(&hook)
 }
 
-// NewZapCore new zap core and hook for zap logger
+// NewZapCore new zap core and hook for zap logger's file sink
 func NewZapCore(opts *Config, fileName string, level zapcore.LevelEnabler) zapcore.Core {
+	core := zapcore.NewCore(newEncoder(opts.FileJson, false), newWriteSyncer(opts, fileName), level)
+	return maybeSample(opts, core)
+}
+
+// NewConsoleCore new zap core for the console (stdout) sink
+func NewConsoleCore(opts *Config, level zapcore.LevelEnabler) zapcore.Core {
+	core := zapcore.NewCore(newEncoder(opts.ConsoleJson, true), zapcore.AddSync(os.Stdout), level)
+	return maybeSample(opts, core)
+}
+
+// newEncoder builds the zapcore.Encoder for a sink. console controls whether
+// the non-JSON encoding uses colorized level output suited to a terminal.
+func newEncoder(jsonEncode, console bool) zapcore.Encoder {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = "timestamp"
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	// Do you need to use json format ?
-	encoder := zapcore.NewConsoleEncoder(encoderConfig)
-	if opts.JsonEncode {
-		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	if jsonEncode {
+		return zapcore.NewJSONEncoder(encoderConfig)
 	}
-	writeSyncer := newWriteSyncer(opts, fileName)
-
-	if opts.Stdout {
-		writeSyncer = zapcore.NewMultiWriteSyncer(zapcore.AddSync(os.Stdout), writeSyncer)
+	if console {
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
-	return zapcore.NewCore(encoder, writeSyncer, level)
+	return zapcore.NewConsoleEncoder(encoderConfig)
 }
 
 func (b *baseLogger) AddCallerSkip(callerSkip int) Logger {
-	return newLoggerWithExtraSkip(b.Logger, callerSkip)
+	return newLoggerWithExtraSkip(b.Logger, callerSkip, b.level)
 }
 
 func (b *baseLogger) WithName(name string) Logger {
 	l := b.Logger.Named(name)
-	return newLoggerWithExtraSkip(l, -1)
+	return newLoggerWithExtraSkip(l, -1, b.level)
 }
 
 func (b *baseLogger) WithValues(keysAndValues ...interface{}) Logger {
 	l := b.Logger.With(handleFields(keysAndValues)...)
-	return newLoggerWithExtraSkip(l, -1)
+	return newLoggerWithExtraSkip(l, -1, b.level)
 }
 
 // WithContext get logger from context, you can set some key value paris into logger with .WithValues method. such as tracId spanId ...
 // example: for gin
 // c.Request = c.Request.WithContext(context.WithValue(ctx, log.LoggerKey, log.WithContext(ctx).WithValues("requestId", requestId, "traceId", traceId)))
 // when you need to print log , you can use log.WithContext(ctx).Warn("some message")
+// The returned logger is additionally enriched with fields from every extractor
+// registered via RegisterContextExtractor (eg the built-in OpenTelemetry trace/span IDs).
 func (b *baseLogger) WithContext(ctx context.Context) Logger {
 	if ctx == nil {
 		return logger
 	}
-	l := ctx.Value(LoggerKey)
-	ctxLogger, ok := l.(Logger)
-	if ok {
-		return ctxLogger
+	base := logger
+	if ctxLogger, ok := ctx.Value(LoggerKey).(Logger); ok {
+		base = ctxLogger
+	}
+	bl, ok := base.(*baseLogger)
+	if !ok {
+		return base
 	}
-	return logger
+	fields := contextFields(ctx)
+	if len(fields) == 0 {
+		return bl
+	}
+	return newLoggerWithExtraSkip(bl.Logger.With(fields...), -1, bl.level)
 }
 
 func (b *baseLogger) Debugf(format string, a ...interface{}) {
@@ -154,6 +245,45 @@ func (b *baseLogger) Fatal(msg string, fields ...zap.Field) {
 	b.Logger.Fatal(msg, fields...)
 }
 
+func (b *baseLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	b.Logger.Debug(msg, handleFields(keysAndValues)...)
+}
+
+func (b *baseLogger) Infow(msg string, keysAndValues ...interface{}) {
+	b.Logger.Info(msg, handleFields(keysAndValues)...)
+}
+
+func (b *baseLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	b.Logger.Warn(msg, handleFields(keysAndValues)...)
+}
+
+func (b *baseLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	b.Logger.Error(msg, handleFields(keysAndValues)...)
+}
+
+func (b *baseLogger) Fatalw(msg string, keysAndValues ...interface{}) {
+	b.Logger.Fatal(msg, handleFields(keysAndValues)...)
+}
+
+func (b *baseLogger) SetLevel(level string) error {
+	if b.level == nil {
+		return errors.New("log: dynamic level control is not enabled for this logger")
+	}
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	b.level.SetLevel(lvl)
+	return nil
+}
+
+func (b *baseLogger) Level() zapcore.Level {
+	if b.level == nil {
+		return zapcore.DebugLevel
+	}
+	return b.level.Level()
+}
+
 // handleFields converts key value pairs to Zap fields
 func handleFields(args []interface{}, additional ...zap.Field) []zap.Field {
 	if len(args) == 0 {
@@ -186,24 +316,33 @@ func handleFields(args []interface{}, additional ...zap.Field) []zap.Field {
 }
 
 // newLoggerWithExtraSkip allows zap logger with callstack skipping
-func newLoggerWithExtraSkip(l *zap.Logger, callerSkip int) Logger {
-	return &baseLogger{l.WithOptions(zap.AddCallerSkip(callerSkip))}
+func newLoggerWithExtraSkip(l *zap.Logger, callerSkip int, level *zap.AtomicLevel) Logger {
+	return &baseLogger{l.WithOptions(zap.AddCallerSkip(callerSkip)), level}
 }
 
 // logLevel string logger level to zap logger level, default is debug level
 func logLevel(level string) zapcore.Level {
+	lvl, _ := parseLevel(level)
+	return lvl
+}
+
+// parseLevel parses a string logger level to a zap logger level, erroring on
+// anything it doesn't recognize rather than silently defaulting.
+func parseLevel(level string) (zapcore.Level, error) {
 	switch strings.ToLower(level) {
 	case "debug":
-		return zapcore.DebugLevel
+		return zapcore.DebugLevel, nil
 	case "info":
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, nil
 	case "warn", "warning":
-		return zapcore.WarnLevel
+		return zapcore.WarnLevel, nil
 	case "error":
-		return zapcore.ErrorLevel
+		return zapcore.ErrorLevel, nil
 	case "fatal":
-		return zapcore.FatalLevel
+		return zapcore.FatalLevel, nil
+	case "":
+		return zapcore.DebugLevel, nil
 	default:
-		return zapcore.DebugLevel
+		return zapcore.DebugLevel, fmt.Errorf("log: unknown level %q", level)
 	}
 }